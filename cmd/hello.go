@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// newHelloCommand is a friendly smoke-test command.
+func newHelloCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "hello [name]",
+		Short: "Say hello",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			name := "World"
+			if len(args) > 0 {
+				name = args[0]
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Hello, %s! 👋\n", name)
+		},
+	}
+}
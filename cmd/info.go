@@ -0,0 +1,24 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// newInfoCommand describes what this demonstration package is for.
+func newInfoCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "info",
+		Short: "Show package info",
+		Run: func(cmd *cobra.Command, args []string) {
+			out := cmd.OutOrStdout()
+			fmt.Fprintln(out, "Zobra Go is a demonstration package for SLSA provenance")
+			fmt.Fprintln(out, "Features:")
+			fmt.Fprintln(out, "- Official SLSA Level 3 provenance")
+			fmt.Fprintln(out, "- Cryptographic attestation")
+			fmt.Fprintln(out, "- Transparency log recording")
+			fmt.Fprintln(out, "- Trusted by slsa-verifier")
+		},
+	}
+}
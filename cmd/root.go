@@ -0,0 +1,50 @@
+// Package cmd implements the zobra-go command-line interface with cobra.
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// BuildInfo carries build-time metadata. It is populated in package main
+// from linker-injected variables and threaded through to the version
+// command.
+type BuildInfo struct {
+	GitVersion   string
+	GitCommit    string
+	GitTreeState string
+	BuildDate    string
+}
+
+// NewRootCommand builds the zobra-go root command and wires in every
+// subcommand.
+func NewRootCommand(info BuildInfo) *cobra.Command {
+	root := &cobra.Command{
+		Use:   "zobra-go",
+		Short: "Zobra Go - SLSA Demonstration Package",
+		Run: func(cmd *cobra.Command, args []string) {
+			printBanner(info.GitVersion)
+			fmt.Println()
+			_ = cmd.Help()
+		},
+	}
+
+	root.AddCommand(newVersionCommand(info))
+	root.AddCommand(newInfoCommand())
+	root.AddCommand(newHelloCommand())
+	root.AddCommand(newVerifyCommand())
+	root.AddCommand(newAttestCommand(info))
+
+	return root
+}
+
+// printBanner prints the decorative banner shown when zobra-go is run
+// without a subcommand.
+func printBanner(gitVersion string) {
+	color.Cyan("🎯 Zobra Go - SLSA Demonstration Package %s", gitVersion)
+	color.Yellow("🔒 This package demonstrates SLSA Level 3 provenance generation")
+	color.Blue("✅ Generated using official SLSA Go builder")
+	color.Magenta("🛡️  Verified with slsa-verifier")
+}
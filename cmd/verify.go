@@ -0,0 +1,314 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/in-toto/in-toto-golang/in_toto"
+	"github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"github.com/sigstore/fulcio/pkg/certificate"
+	rekormodels "github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/sigstore/pkg/signature"
+	sigdsse "github.com/sigstore/sigstore/pkg/signature/dsse"
+	"github.com/spf13/cobra"
+
+	"github.com/yali-gotllib/zobra-slsa/internal/provenance"
+	"github.com/yali-gotllib/zobra-slsa/pkg/rekor"
+)
+
+// provenanceVerifiers routes a statement's predicateType to the Verifier
+// that understands its shape.
+var provenanceVerifiers = provenance.NewRegistry()
+
+// provenanceBundle is the on-disk format expected at --provenance-path: a
+// DSSE-wrapped in-toto attestation alongside the Sigstore/Fulcio signing
+// certificate (and any intermediates) and the Rekor transparency-log entry
+// that was returned when the attestation was uploaded; `verify` rejects a
+// bundle without that entry. This mirrors the shape of a
+// `cosign attest --bundle` output.
+type provenanceBundle struct {
+	DSSEEnvelope dsse.Envelope             `json:"dsseEnvelope"`
+	Cert         string                    `json:"cert"`
+	Chain        string                    `json:"chain,omitempty"`
+	RekorEntry   *rekormodels.LogEntryAnon `json:"rekorEntry,omitempty"`
+}
+
+// verifyOptions are the flags accepted by the `verify` subcommand.
+type verifyOptions struct {
+	provenancePath       string
+	sourceURI            string
+	builderID            string
+	provenanceRepository string
+}
+
+// newVerifyCommand validates the SLSA provenance for the currently running
+// binary: the artifact digest must match, the DSSE envelope must be signed
+// by the certificate it ships with, that certificate must chain to the
+// embedded Fulcio roots, the attestation must have a Rekor transparency-log
+// entry, and the predicate's builderID/buildType/source URI must match
+// what the caller expects.
+func newVerifyCommand() *cobra.Command {
+	opts := verifyOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Validate SLSA provenance for the running binary",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runVerify(cmd, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opts.provenancePath, "provenance-path", "", "path to the DSSE-wrapped provenance attestation")
+	flags.StringVar(&opts.sourceURI, "source-uri", "", "expected source repository URI, e.g. git+https://github.com/org/repo")
+	flags.StringVar(&opts.builderID, "builder-id", "", "expected builder identity recorded in the provenance")
+	flags.StringVar(&opts.provenanceRepository, "provenance-repository", "", "optional repository the provenance itself was fetched from, for logging")
+	_ = cmd.MarkFlagRequired("provenance-path")
+	_ = cmd.MarkFlagRequired("source-uri")
+	_ = cmd.MarkFlagRequired("builder-id")
+
+	return cmd
+}
+
+func runVerify(cmd *cobra.Command, opts verifyOptions) error {
+	artifactPath, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("resolving running binary: %w", err)
+	}
+	artifactDigest, err := sha256File(artifactPath)
+	if err != nil {
+		return fmt.Errorf("hashing running binary: %w", err)
+	}
+
+	bundle, err := loadProvenanceBundle(opts.provenancePath)
+	if err != nil {
+		return fmt.Errorf("loading provenance: %w", err)
+	}
+
+	if bundle.RekorEntry == nil {
+		return fmt.Errorf("provenance has no Rekor transparency-log entry")
+	}
+	envelopeJSON, err := json.Marshal(bundle.DSSEEnvelope)
+	if err != nil {
+		return fmt.Errorf("marshaling DSSE envelope: %w", err)
+	}
+	if err := verifyRekorEntry(bundle.RekorEntry, envelopeJSON); err != nil {
+		return fmt.Errorf("verifying Rekor entry: %w", err)
+	}
+
+	leaf, err := parseLeafCert(bundle)
+	if err != nil {
+		return fmt.Errorf("parsing signing certificate: %w", err)
+	}
+
+	// bundle.RekorEntry.IntegratedTime is authenticated by verifyRekorEntry
+	// above (it's covered by the entry's signed entry timestamp), which is
+	// what makes it usable as a trusted point in time: Fulcio leaf certs are
+	// deliberately short-lived (~10 minutes around issuance), long expired
+	// by the time a user actually runs `verify`, so wall-clock time can't
+	// be used to validate them.
+	integratedTime := time.Unix(*bundle.RekorEntry.IntegratedTime, 0)
+	if err := verifyCertChain(leaf, bundle.Chain, integratedTime); err != nil {
+		return fmt.Errorf("verifying certificate chain: %w", err)
+	}
+
+	if err := verifyCertIdentity(leaf, opts); err != nil {
+		return fmt.Errorf("verifying certificate identity: %w", err)
+	}
+
+	payload, err := verifyEnvelopeSignature(leaf, bundle)
+	if err != nil {
+		return fmt.Errorf("verifying DSSE envelope: %w", err)
+	}
+
+	statement := in_toto.Statement{}
+	if err := json.Unmarshal(payload, &statement); err != nil {
+		return fmt.Errorf("parsing in-toto statement: %w", err)
+	}
+
+	if err := checkSubjectDigest(statement, artifactDigest); err != nil {
+		return err
+	}
+
+	predicate, ok := statement.Predicate.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("predicate has unexpected shape")
+	}
+	verifier, err := provenanceVerifiers.Lookup(statement.PredicateType)
+	if err != nil {
+		return err
+	}
+	if err := verifier.Verify(predicate, provenance.VerifyOptions{
+		BuilderID: opts.builderID,
+		SourceURI: opts.sourceURI,
+	}); err != nil {
+		return err
+	}
+
+	if opts.provenanceRepository != "" {
+		fmt.Fprintf(cmd.OutOrStdout(), "verified: %s matches provenance from %s (builder %s, fetched from %s)\n", artifactPath, opts.sourceURI, opts.builderID, opts.provenanceRepository)
+	} else {
+		fmt.Fprintf(cmd.OutOrStdout(), "verified: %s matches provenance from %s (builder %s)\n", artifactPath, opts.sourceURI, opts.builderID)
+	}
+	return nil
+}
+
+func sha256File(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func loadProvenanceBundle(path string) (*provenanceBundle, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	bundle := &provenanceBundle{}
+	if err := json.Unmarshal(raw, bundle); err != nil {
+		return nil, fmt.Errorf("parsing provenance bundle: %w", err)
+	}
+	if bundle.Cert == "" {
+		return nil, fmt.Errorf("provenance bundle has no signing certificate")
+	}
+	return bundle, nil
+}
+
+// parseLeafCert decodes bundle's signing certificate, shared by the cert
+// chain, identity, and DSSE signature checks that all need it.
+func parseLeafCert(bundle *provenanceBundle) (*x509.Certificate, error) {
+	leafBlock, _ := pem.Decode([]byte(bundle.Cert))
+	if leafBlock == nil {
+		return nil, fmt.Errorf("cert is not valid PEM")
+	}
+	return x509.ParseCertificate(leafBlock.Bytes)
+}
+
+// verifyCertChain checks that leaf chains to a trusted Fulcio root and was
+// valid at verifiedTime. verifiedTime must come from an already-authenticated
+// source (the provenance's Rekor entry, not wall-clock time): Fulcio leaf
+// certs are deliberately short-lived, so they're expected to be expired by
+// the time anyone runs `verify`.
+func verifyCertChain(leaf *x509.Certificate, chain string, verifiedTime time.Time) error {
+	roots, err := fulcioRoots()
+	if err != nil {
+		return err
+	}
+	intermediates, err := fulcioIntermediates()
+	if err != nil {
+		return err
+	}
+	// chain, if present, is attacker-supplied and only ever used as
+	// additional untrusted intermediates to help build the chain - trust
+	// always terminates at the embedded Fulcio roots, never at anything the
+	// provenance bundle itself supplies.
+	if chain != "" && !intermediates.AppendCertsFromPEM([]byte(chain)) {
+		return fmt.Errorf("parsing certificate chain")
+	}
+	opts := x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		CurrentTime:   verifiedTime,
+	}
+	if _, err := leaf.Verify(opts); err != nil {
+		return fmt.Errorf("leaf certificate does not chain to a trusted Fulcio root: %w", err)
+	}
+	return nil
+}
+
+// verifyCertIdentity cross-checks the Fulcio-issued identity embedded in
+// leaf - its SAN and OIDC/CI extensions, the same fields slsa-verifier
+// checks - against what the caller expects, rather than trusting the
+// predicate's self-reported builder.id/source fields. Without this, anyone
+// can obtain their own Fulcio cert and Rekor entry and forge a predicate
+// claiming any builderID/sourceURI they like.
+func verifyCertIdentity(leaf *x509.Certificate, opts verifyOptions) error {
+	var san string
+	if len(leaf.URIs) > 0 {
+		san = leaf.URIs[0].String()
+	}
+	if san != opts.builderID {
+		return fmt.Errorf("certificate SAN %q does not match expected builder %q", san, opts.builderID)
+	}
+
+	exts, err := certificate.ParseExtensions(leaf.Extensions)
+	if err != nil {
+		return fmt.Errorf("parsing Fulcio certificate extensions: %w", err)
+	}
+	sourceRepositoryURI := strings.TrimPrefix(opts.sourceURI, "git+")
+	if exts.SourceRepositoryURI != sourceRepositoryURI {
+		return fmt.Errorf("certificate source repository %q does not match expected source %q", exts.SourceRepositoryURI, sourceRepositoryURI)
+	}
+	return nil
+}
+
+func verifyEnvelopeSignature(leaf *x509.Certificate, bundle *provenanceBundle) ([]byte, error) {
+	baseVerifier, err := signature.LoadVerifier(leaf.PublicKey, 0)
+	if err != nil {
+		return nil, fmt.Errorf("loading verifier for certificate public key: %w", err)
+	}
+	envVerifier := sigdsse.WrapVerifier(baseVerifier)
+
+	envelopeJSON, err := json.Marshal(bundle.DSSEEnvelope)
+	if err != nil {
+		return nil, err
+	}
+	if err := envVerifier.VerifySignature(&noopReader{envelopeJSON}, nil); err != nil {
+		return nil, fmt.Errorf("signature does not match certificate: %w", err)
+	}
+
+	return bundle.DSSEEnvelope.DecodeB64Payload()
+}
+
+// noopReader adapts a byte slice to an io.Reader, since
+// signature.Verifier.VerifySignature reads the message from a reader.
+type noopReader struct {
+	b []byte
+}
+
+func (r *noopReader) Read(p []byte) (int, error) {
+	n := copy(p, r.b)
+	if n == 0 {
+		return 0, io.EOF
+	}
+	r.b = r.b[n:]
+	return n, nil
+}
+
+// verifyRekorEntry cryptographically verifies that the bundled Rekor entry
+// was logged for envelopeJSON specifically (not merely any valid entry),
+// and checks its Merkle inclusion proof, checkpoint signature, and signed
+// entry timestamp against the embedded public-good Rekor key, entirely
+// offline. This is what enforces SLSA Level 3's requirement that
+// provenance be recorded in a transparency log, rather than merely
+// trusting whatever the bundle claims.
+func verifyRekorEntry(entry *rekormodels.LogEntryAnon, envelopeJSON []byte) error {
+	_, err := rekor.VerifyInclusionOffline(entry, envelopeJSON, nil)
+	return err
+}
+
+func checkSubjectDigest(statement in_toto.Statement, artifactDigest string) error {
+	for _, subject := range statement.Subject {
+		if digest, ok := subject.Digest["sha256"]; ok && digest == artifactDigest {
+			return nil
+		}
+	}
+	return fmt.Errorf("provenance subject does not list sha256:%s", artifactDigest)
+}
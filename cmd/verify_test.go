@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/url"
+	"testing"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/in-toto/in-toto-golang/in_toto"
+	"github.com/sigstore/fulcio/pkg/certificate"
+	rekormodels "github.com/sigstore/rekor/pkg/generated/models"
+
+	"github.com/yali-gotllib/zobra-slsa/pkg/rekor"
+)
+
+// newLeafCert builds a self-signed certificate shaped like a Fulcio-issued
+// one: its SAN is the builder's workflow ref URI and it carries the
+// OIDC/CI extensions Fulcio embeds, for verifyCertIdentity to check.
+func newLeafCert(t *testing.T, sanURI, sourceRepositoryURI string) *x509.Certificate {
+	t.Helper()
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	exts, err := certificate.Extensions{
+		Issuer:              "https://token.actions.githubusercontent.com",
+		SourceRepositoryURI: sourceRepositoryURI,
+	}.Render()
+	if err != nil {
+		t.Fatalf("rendering Fulcio extensions: %v", err)
+	}
+
+	san, err := url.Parse(sanURI)
+	if err != nil {
+		t.Fatalf("parsing SAN URI: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:    big.NewInt(1),
+		URIs:            []*url.URL{san},
+		ExtraExtensions: exts,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &priv.PublicKey, priv)
+	if err != nil {
+		t.Fatalf("creating certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("parsing certificate: %v", err)
+	}
+	return cert
+}
+
+func TestVerifyCertIdentityAcceptsMatchingIdentity(t *testing.T) {
+	builderID := "https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_go_slsa3.yml@refs/tags/v1.9.0"
+	sourceURI := "git+https://github.com/org/repo"
+
+	leaf := newLeafCert(t, builderID, "https://github.com/org/repo")
+	if err := verifyCertIdentity(leaf, verifyOptions{builderID: builderID, sourceURI: sourceURI}); err != nil {
+		t.Fatalf("verifyCertIdentity() = %v, want nil", err)
+	}
+}
+
+func TestVerifyCertIdentityRejectsBuilderMismatch(t *testing.T) {
+	builderID := "https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_go_slsa3.yml@refs/tags/v1.9.0"
+	sourceURI := "git+https://github.com/org/repo"
+
+	// The cert's SAN names a different builder than the one the predicate
+	// claims - an attacker's own Fulcio cert, attached to a forged
+	// predicate that claims the victim's builderID.
+	leaf := newLeafCert(t, "https://github.com/attacker/repo/.github/workflows/build.yml@refs/heads/main", "https://github.com/org/repo")
+	err := verifyCertIdentity(leaf, verifyOptions{builderID: builderID, sourceURI: sourceURI})
+	if err == nil {
+		t.Fatal("verifyCertIdentity() = nil, want error for builder SAN mismatch")
+	}
+}
+
+func TestVerifyCertIdentityRejectsSourceMismatch(t *testing.T) {
+	builderID := "https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_go_slsa3.yml@refs/tags/v1.9.0"
+	sourceURI := "git+https://github.com/org/repo"
+
+	leaf := newLeafCert(t, builderID, "https://github.com/attacker/repo")
+	err := verifyCertIdentity(leaf, verifyOptions{builderID: builderID, sourceURI: sourceURI})
+	if err == nil {
+		t.Fatal("verifyCertIdentity() = nil, want error for source repository mismatch")
+	}
+}
+
+func TestCheckSubjectDigestMatches(t *testing.T) {
+	statement := in_toto.Statement{
+		StatementHeader: in_toto.StatementHeader{
+			Subject: []in_toto.Subject{
+				{Digest: map[string]string{"sha256": "abc123"}},
+			},
+		},
+	}
+	if err := checkSubjectDigest(statement, "abc123"); err != nil {
+		t.Fatalf("checkSubjectDigest() = %v, want nil", err)
+	}
+}
+
+func TestCheckSubjectDigestRejectsMismatch(t *testing.T) {
+	statement := in_toto.Statement{
+		StatementHeader: in_toto.StatementHeader{
+			Subject: []in_toto.Subject{
+				{Digest: map[string]string{"sha256": "abc123"}},
+			},
+		},
+	}
+	if err := checkSubjectDigest(statement, "deadbeef"); err == nil {
+		t.Fatal("checkSubjectDigest() = nil, want error for mismatched digest")
+	}
+}
+
+// hashedrekordEntryFor builds a real, validly-signed hashedrekord v0.0.1
+// Rekor entry attesting to payload's SHA256 digest. It stands in for the
+// intoto entry logged for a DSSE envelope: both kinds report the attested
+// digest through ArtifactHash.
+func hashedrekordEntryFor(t *testing.T, payload []byte) *rekormodels.LogEntryAnon {
+	t.Helper()
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	pubDER, err := x509.MarshalPKIXPublicKey(&priv.PublicKey)
+	if err != nil {
+		t.Fatalf("marshaling public key: %v", err)
+	}
+	pubPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER})
+
+	sum := sha256.Sum256(payload)
+	sig, err := ecdsa.SignASN1(rand.Reader, priv, sum[:])
+	if err != nil {
+		t.Fatalf("signing digest: %v", err)
+	}
+
+	rekord := rekormodels.Hashedrekord{
+		APIVersion: swag.String("0.0.1"),
+		Spec: &rekormodels.HashedrekordV001Schema{
+			Signature: &rekormodels.HashedrekordV001SchemaSignature{
+				Content: strfmt.Base64(sig),
+				PublicKey: &rekormodels.HashedrekordV001SchemaSignaturePublicKey{
+					Content: strfmt.Base64(pubPEM),
+				},
+			},
+			Data: &rekormodels.HashedrekordV001SchemaData{
+				Hash: &rekormodels.HashedrekordV001SchemaDataHash{
+					Algorithm: swag.String(rekormodels.HashedrekordV001SchemaDataHashAlgorithmSha256),
+					Value:     swag.String(hex.EncodeToString(sum[:])),
+				},
+			},
+		},
+	}
+
+	body, err := json.Marshal(&rekord)
+	if err != nil {
+		t.Fatalf("marshaling hashedrekord entry: %v", err)
+	}
+
+	return &rekormodels.LogEntryAnon{Body: base64.StdEncoding.EncodeToString(body)}
+}
+
+func TestVerifyRekorEntryRejectsEntryForUnrelatedArtifact(t *testing.T) {
+	// A genuine, validly-signed Rekor entry for some other artifact must
+	// not "verify" a forged envelope just because the entry itself is
+	// internally consistent.
+	entry := hashedrekordEntryFor(t, []byte("some unrelated artifact"))
+	forgedEnvelopeJSON := []byte(`{"payload":"Zm9yZ2Vk","payloadType":"application/vnd.in-toto+json","signatures":[]}`)
+
+	err := verifyRekorEntry(entry, forgedEnvelopeJSON)
+	if !errors.Is(err, rekor.ErrPayloadMismatch) {
+		t.Fatalf("verifyRekorEntry() = %v, want ErrPayloadMismatch", err)
+	}
+}
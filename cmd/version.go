@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime"
+
+	"github.com/spf13/cobra"
+)
+
+// versionInfo is the structured build metadata reported by `version`.
+type versionInfo struct {
+	GitVersion   string `json:"gitVersion"`
+	GitCommit    string `json:"gitCommit"`
+	GitTreeState string `json:"gitTreeState"`
+	BuildDate    string `json:"buildDate"`
+	GoVersion    string `json:"goVersion"`
+	Compiler     string `json:"compiler"`
+	Platform     string `json:"platform"`
+}
+
+// newVersionCommand reports the metadata baked into the binary at build
+// time alongside the toolchain it was built with, so downstream SLSA
+// verifiers can correlate a running binary with the buildConfig recorded
+// in its provenance.
+func newVersionCommand(info BuildInfo) *cobra.Command {
+	var asJSON bool
+
+	cmd := &cobra.Command{
+		Use:   "version",
+		Short: "Print build version information",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			v := versionInfo{
+				GitVersion:   info.GitVersion,
+				GitCommit:    info.GitCommit,
+				GitTreeState: info.GitTreeState,
+				BuildDate:    info.BuildDate,
+				GoVersion:    runtime.Version(),
+				Compiler:     runtime.Compiler,
+				Platform:     fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH),
+			}
+
+			out := cmd.OutOrStdout()
+			if asJSON {
+				enc := json.NewEncoder(out)
+				enc.SetIndent("", "  ")
+				return enc.Encode(v)
+			}
+
+			fmt.Fprintf(out, "GitVersion:   %s\n", v.GitVersion)
+			fmt.Fprintf(out, "GitCommit:    %s\n", v.GitCommit)
+			fmt.Fprintf(out, "GitTreeState: %s\n", v.GitTreeState)
+			fmt.Fprintf(out, "BuildDate:    %s\n", v.BuildDate)
+			fmt.Fprintf(out, "GoVersion:    %s\n", v.GoVersion)
+			fmt.Fprintf(out, "Compiler:     %s\n", v.Compiler)
+			fmt.Fprintf(out, "Platform:     %s\n", v.Platform)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&asJSON, "json", false, "print version information as JSON")
+	return cmd
+}
@@ -0,0 +1,19 @@
+package provenance
+
+import "errors"
+
+// ErrUnsupportedPredicateType is returned when no Verifier is registered
+// for a predicateType found in a provenance statement.
+var ErrUnsupportedPredicateType = errors.New("unsupported predicate type")
+
+// ErrInvalidBuildType is returned when a predicate's buildType is not on
+// the allowlist for the builderID that produced it.
+var ErrInvalidBuildType = errors.New("buildType is not allowed for this builder")
+
+// ErrBuilderMismatch is returned when a predicate's builder.id does not
+// match the builder the caller expected.
+var ErrBuilderMismatch = errors.New("builder ID does not match expected builder")
+
+// ErrSourceMismatch is returned when a predicate's source repository URI
+// does not match the source the caller expected.
+var ErrSourceMismatch = errors.New("source URI does not match expected source")
@@ -0,0 +1,50 @@
+// Package provenance verifies SLSA provenance predicates. Different
+// builders emit different predicate shapes under the same in-toto
+// Statement envelope, so callers look up a Verifier by the predicateType
+// extracted from the envelope and dispatch to it rather than assuming a
+// single fixed schema.
+package provenance
+
+import "fmt"
+
+// VerifyOptions carries the caller's expectations for a provenance
+// predicate: the builder that is trusted to have produced it and the
+// source repository it should have built from.
+type VerifyOptions struct {
+	BuilderID string
+	SourceURI string
+}
+
+// Verifier validates a decoded provenance predicate of a specific SLSA
+// predicateType.
+type Verifier interface {
+	// PredicateType returns the predicateType URI this Verifier handles.
+	PredicateType() string
+	// Verify checks predicate against opts, returning a descriptive error
+	// on any mismatch.
+	Verify(predicate map[string]interface{}, opts VerifyOptions) error
+}
+
+// Registry resolves a predicateType URI to the Verifier that handles it.
+type Registry map[string]Verifier
+
+// NewRegistry returns a Registry populated with the built-in verifiers:
+// the classic SLSA v0.2 predicate produced by the original Go builder, and
+// the SLSA v1.0 predicate produced by newer BYOB and npm-style builders.
+func NewRegistry() Registry {
+	r := Registry{}
+	for _, v := range []Verifier{NewSLSA02Verifier(), NewSLSA1Verifier()} {
+		r[v.PredicateType()] = v
+	}
+	return r
+}
+
+// Lookup returns the Verifier registered for predicateType, or
+// ErrUnsupportedPredicateType if none is registered.
+func (r Registry) Lookup(predicateType string) (Verifier, error) {
+	v, ok := r[predicateType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedPredicateType, predicateType)
+	}
+	return v, nil
+}
@@ -0,0 +1,34 @@
+package provenance
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRegistryLookupUnsupportedPredicateType(t *testing.T) {
+	r := NewRegistry()
+	_, err := r.Lookup("https://example.com/unknown-predicate")
+	if !errors.Is(err, ErrUnsupportedPredicateType) {
+		t.Fatalf("Lookup() = %v, want ErrUnsupportedPredicateType", err)
+	}
+}
+
+func TestRegistryLookupRoutesByPredicateType(t *testing.T) {
+	r := NewRegistry()
+
+	v, err := r.Lookup(slsa02PredicateType)
+	if err != nil {
+		t.Fatalf("Lookup(%q) = %v, want nil", slsa02PredicateType, err)
+	}
+	if v.PredicateType() != slsa02PredicateType {
+		t.Fatalf("Lookup(%q) returned a verifier for %q", slsa02PredicateType, v.PredicateType())
+	}
+
+	v, err = r.Lookup(slsa1PredicateType)
+	if err != nil {
+		t.Fatalf("Lookup(%q) = %v, want nil", slsa1PredicateType, err)
+	}
+	if v.PredicateType() != slsa1PredicateType {
+		t.Fatalf("Lookup(%q) returned a verifier for %q", slsa1PredicateType, v.PredicateType())
+	}
+}
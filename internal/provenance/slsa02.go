@@ -0,0 +1,58 @@
+package provenance
+
+import "fmt"
+
+// slsa02PredicateType is the predicateType of the original SLSA provenance
+// schema, still emitted by the classic SLSA GitHub generator Go builder.
+const slsa02PredicateType = "https://slsa.dev/provenance/v0.2"
+
+// slsa02BuildTypeAllowlist restricts which buildTypes a given builderID is
+// allowed to claim, so that a v0.2 provenance from an unexpected builder
+// (or an unexpected buildType from a known builder) is rejected rather
+// than silently trusted.
+var slsa02BuildTypeAllowlist = map[string][]string{
+	"https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_go_slsa3.yml@refs/tags/v1.9.0": {
+		"https://github.com/slsa-framework/slsa-github-generator/go@v1",
+	},
+}
+
+// SLSA02Verifier verifies predicates with predicateType
+// https://slsa.dev/provenance/v0.2.
+type SLSA02Verifier struct {
+	buildTypeAllowlist map[string][]string
+}
+
+// NewSLSA02Verifier returns a SLSA02Verifier using the built-in buildType
+// allowlist.
+func NewSLSA02Verifier() *SLSA02Verifier {
+	return &SLSA02Verifier{buildTypeAllowlist: slsa02BuildTypeAllowlist}
+}
+
+// PredicateType implements Verifier.
+func (v *SLSA02Verifier) PredicateType() string {
+	return slsa02PredicateType
+}
+
+// Verify implements Verifier.
+func (v *SLSA02Verifier) Verify(predicate map[string]interface{}, opts VerifyOptions) error {
+	builderID, _ := stringPath(predicate, "builder", "id")
+	if builderID != opts.BuilderID {
+		return fmt.Errorf("%w: got %q, want %q", ErrBuilderMismatch, builderID, opts.BuilderID)
+	}
+
+	buildType, _ := predicate["buildType"].(string)
+	allowed, known := v.buildTypeAllowlist[builderID]
+	if !known {
+		return fmt.Errorf("%w: no buildType allowlist for builder %q", ErrInvalidBuildType, builderID)
+	}
+	if !contains(allowed, buildType) {
+		return fmt.Errorf("%w: %q is not allowed for builder %q", ErrInvalidBuildType, buildType, builderID)
+	}
+
+	sourceURI, _ := stringPath(predicate, "invocation", "configSource", "uri")
+	if sourceURI != opts.SourceURI {
+		return fmt.Errorf("%w: got %q, want %q", ErrSourceMismatch, sourceURI, opts.SourceURI)
+	}
+
+	return nil
+}
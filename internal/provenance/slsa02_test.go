@@ -0,0 +1,61 @@
+package provenance
+
+import (
+	"errors"
+	"testing"
+)
+
+func slsa02Predicate(builderID, buildType, sourceURI string) map[string]interface{} {
+	return map[string]interface{}{
+		"builder":   map[string]interface{}{"id": builderID},
+		"buildType": buildType,
+		"invocation": map[string]interface{}{
+			"configSource": map[string]interface{}{"uri": sourceURI},
+		},
+	}
+}
+
+func TestSLSA02VerifierAllowsKnownBuilderAndBuildType(t *testing.T) {
+	builderID := "https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_go_slsa3.yml@refs/tags/v1.9.0"
+	buildType := "https://github.com/slsa-framework/slsa-github-generator/go@v1"
+	sourceURI := "git+https://github.com/org/repo"
+
+	v := NewSLSA02Verifier()
+	predicate := slsa02Predicate(builderID, buildType, sourceURI)
+	if err := v.Verify(predicate, VerifyOptions{BuilderID: builderID, SourceURI: sourceURI}); err != nil {
+		t.Fatalf("Verify() = %v, want nil", err)
+	}
+}
+
+func TestSLSA02VerifierRejectsDisallowedBuildType(t *testing.T) {
+	builderID := "https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_go_slsa3.yml@refs/tags/v1.9.0"
+	sourceURI := "git+https://github.com/org/repo"
+
+	v := NewSLSA02Verifier()
+	predicate := slsa02Predicate(builderID, "https://attacker.example/evil@v1", sourceURI)
+	err := v.Verify(predicate, VerifyOptions{BuilderID: builderID, SourceURI: sourceURI})
+	if !errors.Is(err, ErrInvalidBuildType) {
+		t.Fatalf("Verify() = %v, want ErrInvalidBuildType", err)
+	}
+}
+
+func TestSLSA02VerifierRejectsUnknownBuilder(t *testing.T) {
+	builderID := "https://attacker.example/builder"
+	sourceURI := "git+https://github.com/org/repo"
+
+	v := NewSLSA02Verifier()
+	predicate := slsa02Predicate(builderID, "anything", sourceURI)
+	err := v.Verify(predicate, VerifyOptions{BuilderID: builderID, SourceURI: sourceURI})
+	if !errors.Is(err, ErrInvalidBuildType) {
+		t.Fatalf("Verify() = %v, want ErrInvalidBuildType for a builder with no allowlist entry", err)
+	}
+}
+
+func TestSLSA02VerifierRejectsBuilderMismatch(t *testing.T) {
+	v := NewSLSA02Verifier()
+	predicate := slsa02Predicate("https://github.com/actual-builder", "irrelevant", "git+https://github.com/org/repo")
+	err := v.Verify(predicate, VerifyOptions{BuilderID: "https://github.com/expected-builder", SourceURI: "git+https://github.com/org/repo"})
+	if !errors.Is(err, ErrBuilderMismatch) {
+		t.Fatalf("Verify() = %v, want ErrBuilderMismatch", err)
+	}
+}
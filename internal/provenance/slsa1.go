@@ -0,0 +1,64 @@
+package provenance
+
+import "fmt"
+
+// slsa1PredicateType is the predicateType of the SLSA v1.0 provenance
+// schema. Both the newer BYOB ("bring your own builder") reusable
+// workflows and npm's provenance-publishing workflow emit this predicate
+// type, distinguished from each other only by buildType and builder.id.
+const slsa1PredicateType = "https://slsa.dev/provenance/v1"
+
+// slsa1BuildTypeAllowlist restricts which buildTypes a given builderID is
+// allowed to claim. A v1.0 provenance from a builderID that isn't listed
+// here at all is rejected outright; a builderID that is listed but claims
+// a buildType outside its entry is rejected as ErrInvalidBuildType.
+var slsa1BuildTypeAllowlist = map[string][]string{
+	"https://github.com/slsa-framework/slsa-github-generator/.github/workflows/builder_go_slsa3.yml@refs/tags/v2.0.0": {
+		"https://github.com/slsa-framework/slsa-github-generator/go@v1",
+	},
+	"https://github.com/actions/runner": {
+		"https://github.com/npm/cli/gh-actions/v2",
+		"https://slsa-framework.github.io/github-actions-buildtypes/workflow/v1",
+	},
+}
+
+// SLSA1Verifier verifies predicates with predicateType
+// https://slsa.dev/provenance/v1, including npm/BYOB variants.
+type SLSA1Verifier struct {
+	buildTypeAllowlist map[string][]string
+}
+
+// NewSLSA1Verifier returns a SLSA1Verifier using the built-in buildType
+// allowlist.
+func NewSLSA1Verifier() *SLSA1Verifier {
+	return &SLSA1Verifier{buildTypeAllowlist: slsa1BuildTypeAllowlist}
+}
+
+// PredicateType implements Verifier.
+func (v *SLSA1Verifier) PredicateType() string {
+	return slsa1PredicateType
+}
+
+// Verify implements Verifier.
+func (v *SLSA1Verifier) Verify(predicate map[string]interface{}, opts VerifyOptions) error {
+	builderID, _ := stringPath(predicate, "runDetails", "builder", "id")
+	if builderID != opts.BuilderID {
+		return fmt.Errorf("%w: got %q, want %q", ErrBuilderMismatch, builderID, opts.BuilderID)
+	}
+
+	buildType, _ := stringPath(predicate, "buildDefinition", "buildType")
+	allowed, known := v.buildTypeAllowlist[builderID]
+	if !known {
+		return fmt.Errorf("%w: no buildType allowlist for builder %q", ErrInvalidBuildType, builderID)
+	}
+	if !contains(allowed, buildType) {
+		return fmt.Errorf("%w: %q is not allowed for builder %q", ErrInvalidBuildType, buildType, builderID)
+	}
+
+	sourceURI, _ := stringPath(predicate, "buildDefinition", "externalParameters", "workflow", "repository")
+	if sourceURI != opts.SourceURI {
+		return fmt.Errorf("%w: got %q, want %q", ErrSourceMismatch, sourceURI, opts.SourceURI)
+	}
+
+	return nil
+}
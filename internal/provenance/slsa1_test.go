@@ -0,0 +1,56 @@
+package provenance
+
+import (
+	"errors"
+	"testing"
+)
+
+func slsa1Predicate(builderID, buildType, sourceURI string) map[string]interface{} {
+	return map[string]interface{}{
+		"buildDefinition": map[string]interface{}{
+			"buildType": buildType,
+			"externalParameters": map[string]interface{}{
+				"workflow": map[string]interface{}{"repository": sourceURI},
+			},
+		},
+		"runDetails": map[string]interface{}{
+			"builder": map[string]interface{}{"id": builderID},
+		},
+	}
+}
+
+func TestSLSA1VerifierAllowsKnownBuilderAndBuildType(t *testing.T) {
+	builderID := "https://github.com/actions/runner"
+	buildType := "https://github.com/npm/cli/gh-actions/v2"
+	sourceURI := "git+https://github.com/org/repo"
+
+	v := NewSLSA1Verifier()
+	predicate := slsa1Predicate(builderID, buildType, sourceURI)
+	if err := v.Verify(predicate, VerifyOptions{BuilderID: builderID, SourceURI: sourceURI}); err != nil {
+		t.Fatalf("Verify() = %v, want nil", err)
+	}
+}
+
+func TestSLSA1VerifierRejectsDisallowedBuildType(t *testing.T) {
+	builderID := "https://github.com/actions/runner"
+	sourceURI := "git+https://github.com/org/repo"
+
+	v := NewSLSA1Verifier()
+	predicate := slsa1Predicate(builderID, "https://attacker.example/evil@v1", sourceURI)
+	err := v.Verify(predicate, VerifyOptions{BuilderID: builderID, SourceURI: sourceURI})
+	if !errors.Is(err, ErrInvalidBuildType) {
+		t.Fatalf("Verify() = %v, want ErrInvalidBuildType", err)
+	}
+}
+
+func TestSLSA1VerifierRejectsUnknownBuilder(t *testing.T) {
+	builderID := "https://attacker.example/builder"
+	sourceURI := "git+https://github.com/org/repo"
+
+	v := NewSLSA1Verifier()
+	predicate := slsa1Predicate(builderID, "anything", sourceURI)
+	err := v.Verify(predicate, VerifyOptions{BuilderID: builderID, SourceURI: sourceURI})
+	if !errors.Is(err, ErrInvalidBuildType) {
+		t.Fatalf("Verify() = %v, want ErrInvalidBuildType for a builder with no allowlist entry", err)
+	}
+}
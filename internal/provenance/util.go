@@ -0,0 +1,30 @@
+package provenance
+
+// stringPath walks a chain of nested map[string]interface{} values and
+// returns the string found at the end of it, if the whole path exists and
+// every intermediate value is itself a map.
+func stringPath(predicate map[string]interface{}, path ...string) (string, bool) {
+	var current interface{} = predicate
+	for _, key := range path {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return "", false
+		}
+		current, ok = m[key]
+		if !ok {
+			return "", false
+		}
+	}
+	s, ok := current.(string)
+	return s, ok
+}
+
+// contains reports whether needle is present in haystack.
+func contains(haystack []string, needle string) bool {
+	for _, s := range haystack {
+		if s == needle {
+			return true
+		}
+	}
+	return false
+}
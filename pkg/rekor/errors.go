@@ -0,0 +1,12 @@
+package rekor
+
+import "errors"
+
+// ErrNoMatchingEntry is returned when the Rekor index has no log entry for
+// the attestation's payload hash.
+var ErrNoMatchingEntry = errors.New("no Rekor entry found for attestation")
+
+// ErrPayloadMismatch is returned when a Rekor entry is internally
+// consistent and well-signed but was logged for a different artifact than
+// the one being verified.
+var ErrPayloadMismatch = errors.New("Rekor entry does not match attestation payload")
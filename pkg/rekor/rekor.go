@@ -0,0 +1,217 @@
+// Package rekor verifies that a DSSE-wrapped attestation was recorded in a
+// Rekor transparency log: it fetches (or accepts a bundled copy of) the
+// matching log entry and checks its inclusion proof, checkpoint, and signed
+// entry timestamp, the same checks `cosign verify` performs before it will
+// trust an attestation.
+package rekor
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-openapi/runtime"
+	"github.com/secure-systems-lab/go-securesystemslib/dsse"
+	rekorclient "github.com/sigstore/rekor/pkg/client"
+	genclient "github.com/sigstore/rekor/pkg/generated/client"
+	"github.com/sigstore/rekor/pkg/generated/client/entries"
+	"github.com/sigstore/rekor/pkg/generated/client/index"
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/types"
+	_ "github.com/sigstore/rekor/pkg/types/hashedrekord"        // register the hashedrekord entry kind
+	_ "github.com/sigstore/rekor/pkg/types/hashedrekord/v0.0.1" // register its v0.0.1 schema
+	_ "github.com/sigstore/rekor/pkg/types/intoto"              // register the intoto entry kind, used for DSSE attestations
+	_ "github.com/sigstore/rekor/pkg/types/intoto/v0.0.1"       // register its v0.0.1 schema
+	_ "github.com/sigstore/rekor/pkg/types/intoto/v0.0.2"       // register its v0.0.2 schema
+	rekorverify "github.com/sigstore/rekor/pkg/verify"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/signature"
+)
+
+// DefaultServerURL is the public-good Rekor instance used when callers
+// don't have a private log of their own.
+const DefaultServerURL = "https://rekor.sigstore.dev"
+
+// defaultPublicKeyPEM is the public-good Rekor instance's log verification
+// key, vendored so offline verification doesn't depend on fetching it at
+// runtime. It is the same key sigstore-go ships via its embedded TUF root.
+const defaultPublicKeyPEM = `-----BEGIN PUBLIC KEY-----
+MFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAE2G2Y+2tabdTV5BcGiBIx0a9fAFwr
+kBbmLSGtks4L3qX6yYY0zufBnhC8Ur/iy55GhWP/9A/bY2LhC30M9+RYtw==
+-----END PUBLIC KEY-----`
+
+// VerifyInclusion looks up the Rekor transparency-log entry whose body
+// matches envelope's payload, then verifies its Merkle inclusion proof,
+// checkpoint signature, and signed entry timestamp. Pass a nil publicKey to
+// verify against the embedded public-good Rekor key; pass a non-nil one to
+// verify against a private Rekor instance's key instead.
+//
+// VerifyInclusion requires network access to DefaultServerURL. Callers that
+// already have the log entry bundled alongside their attestation (as
+// produced by `cosign bundle`) should use VerifyInclusionOffline instead.
+func VerifyInclusion(ctx context.Context, envelope []byte, publicKey crypto.PublicKey) (*models.LogEntryAnon, error) {
+	verifier, err := loadVerifier(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := rekorclient.GetRekorClient(DefaultServerURL)
+	if err != nil {
+		return nil, fmt.Errorf("building Rekor client: %w", err)
+	}
+
+	hash, err := payloadHash(envelope)
+	if err != nil {
+		return nil, err
+	}
+
+	uuid, err := findEntryUUID(ctx, client, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := fetchEntry(ctx, client, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkEntryMatchesPayload(entry, hash); err != nil {
+		return nil, err
+	}
+
+	if err := rekorverify.VerifyLogEntry(ctx, entry, verifier); err != nil {
+		return nil, fmt.Errorf("verifying Rekor log entry: %w", err)
+	}
+
+	return entry, nil
+}
+
+// VerifyInclusionOffline verifies a Rekor log entry that was bundled
+// alongside envelope, without making any network calls: it requires
+// entry.Body to be the log entry for envelope's own payload (not merely any
+// valid, unrelated entry) and then verifies entry's Merkle inclusion proof,
+// checkpoint signature, and signed entry timestamp. Pass a nil publicKey to
+// verify against the embedded public-good Rekor key.
+func VerifyInclusionOffline(entry *models.LogEntryAnon, envelope []byte, publicKey crypto.PublicKey) (*models.LogEntryAnon, error) {
+	verifier, err := loadVerifier(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := payloadHash(envelope)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkEntryMatchesPayload(entry, hash); err != nil {
+		return nil, err
+	}
+
+	if err := rekorverify.VerifyLogEntry(context.Background(), entry, verifier); err != nil {
+		return nil, fmt.Errorf("verifying Rekor log entry: %w", err)
+	}
+
+	return entry, nil
+}
+
+// loadVerifier builds the signature.Verifier used to check a log entry's
+// checkpoint and signed entry timestamp, falling back to the embedded
+// public-good Rekor key when publicKey is nil.
+func loadVerifier(publicKey crypto.PublicKey) (signature.Verifier, error) {
+	if publicKey == nil {
+		pub, err := cryptoutils.UnmarshalPEMToPublicKey([]byte(defaultPublicKeyPEM))
+		if err != nil {
+			return nil, fmt.Errorf("parsing embedded Rekor public key: %w", err)
+		}
+		publicKey = pub
+	}
+	return signature.LoadVerifier(publicKey, crypto.SHA256)
+}
+
+// payloadHash returns the hex-encoded SHA256 digest of envelope's decoded
+// payload, which is how Rekor's index keys DSSE entries.
+func payloadHash(envelope []byte) (string, error) {
+	env := dsse.Envelope{}
+	if err := json.Unmarshal(envelope, &env); err != nil {
+		return "", fmt.Errorf("parsing DSSE envelope: %w", err)
+	}
+	payload, err := env.DecodeB64Payload()
+	if err != nil {
+		return "", fmt.Errorf("decoding DSSE payload: %w", err)
+	}
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// checkEntryMatchesPayload confirms entry was logged for the artifact whose
+// SHA256 digest is hash, rather than some other, unrelated attestation that
+// merely happens to be a validly-signed Rekor entry.
+func checkEntryMatchesPayload(entry *models.LogEntryAnon, hash string) error {
+	entryHash, err := entryArtifactHash(entry)
+	if err != nil {
+		return err
+	}
+	if entryHash != "sha256:"+hash {
+		return fmt.Errorf("%w: entry is for %s, envelope payload hashes to sha256:%s", ErrPayloadMismatch, entryHash, hash)
+	}
+	return nil
+}
+
+// entryArtifactHash decodes entry.Body into the versioned Rekor entry type
+// it declares (intoto or hashedrekord) and returns the hex-encoded,
+// algorithm-prefixed digest of the artifact it attests to, e.g.
+// "sha256:abcd...".
+func entryArtifactHash(entry *models.LogEntryAnon) (string, error) {
+	bodyStr, ok := entry.Body.(string)
+	if !ok {
+		return "", fmt.Errorf("entry body has unexpected type %T", entry.Body)
+	}
+	body, err := base64.StdEncoding.DecodeString(bodyStr)
+	if err != nil {
+		return "", fmt.Errorf("decoding entry body: %w", err)
+	}
+
+	pe, err := models.UnmarshalProposedEntry(bytes.NewReader(body), runtime.JSONConsumer())
+	if err != nil {
+		return "", fmt.Errorf("unmarshaling entry body: %w", err)
+	}
+	impl, err := types.UnmarshalEntry(pe)
+	if err != nil {
+		return "", fmt.Errorf("unmarshaling entry for kind %q: %w", pe.Kind(), err)
+	}
+	return impl.ArtifactHash()
+}
+
+// findEntryUUID searches the Rekor index for an entry whose artifact digest
+// is hash, returning ErrNoMatchingEntry if none is logged.
+func findEntryUUID(ctx context.Context, client *genclient.Rekor, hash string) (string, error) {
+	params := index.NewSearchIndexParamsWithContext(ctx).WithQuery(&models.SearchIndex{
+		Hash: "sha256:" + hash,
+	})
+	resp, err := client.Index.SearchIndex(params)
+	if err != nil {
+		return "", fmt.Errorf("searching Rekor index: %w", err)
+	}
+	if len(resp.Payload) == 0 {
+		return "", ErrNoMatchingEntry
+	}
+	return resp.Payload[0], nil
+}
+
+// fetchEntry retrieves the full log entry for uuid.
+func fetchEntry(ctx context.Context, client *genclient.Rekor, uuid string) (*models.LogEntryAnon, error) {
+	params := entries.NewGetLogEntryByUUIDParamsWithContext(ctx).WithEntryUUID(uuid)
+	resp, err := client.Entries.GetLogEntryByUUID(params)
+	if err != nil {
+		return nil, fmt.Errorf("fetching Rekor entry %s: %w", uuid, err)
+	}
+	for _, entry := range resp.Payload {
+		entry := entry
+		return &entry, nil
+	}
+	return nil, ErrNoMatchingEntry
+}
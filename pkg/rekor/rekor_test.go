@@ -0,0 +1,116 @@
+package rekor
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"testing"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/sigstore/rekor/pkg/generated/models"
+)
+
+// newHashedrekordEntry builds a LogEntryAnon whose Body is a real,
+// validly-signed hashedrekord v0.0.1 entry attesting to payload's SHA256
+// digest. It stands in for the intoto entry logged for a DSSE envelope:
+// both kinds report the attested digest through ArtifactHash.
+func newHashedrekordEntry(t *testing.T, payload []byte) *models.LogEntryAnon {
+	t.Helper()
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	pubDER, err := x509.MarshalPKIXPublicKey(&priv.PublicKey)
+	if err != nil {
+		t.Fatalf("marshaling public key: %v", err)
+	}
+	pubPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER})
+
+	sum := sha256.Sum256(payload)
+	sig, err := ecdsa.SignASN1(rand.Reader, priv, sum[:])
+	if err != nil {
+		t.Fatalf("signing digest: %v", err)
+	}
+
+	hashValue := hex.EncodeToString(sum[:])
+	rekord := models.Hashedrekord{
+		APIVersion: swag.String("0.0.1"),
+		Spec: &models.HashedrekordV001Schema{
+			Signature: &models.HashedrekordV001SchemaSignature{
+				Content: strfmt.Base64(sig),
+				PublicKey: &models.HashedrekordV001SchemaSignaturePublicKey{
+					Content: strfmt.Base64(pubPEM),
+				},
+			},
+			Data: &models.HashedrekordV001SchemaData{
+				Hash: &models.HashedrekordV001SchemaDataHash{
+					Algorithm: swag.String(models.HashedrekordV001SchemaDataHashAlgorithmSha256),
+					Value:     swag.String(hashValue),
+				},
+			},
+		},
+	}
+
+	body, err := json.Marshal(&rekord)
+	if err != nil {
+		t.Fatalf("marshaling hashedrekord entry: %v", err)
+	}
+
+	return &models.LogEntryAnon{
+		Body: base64.StdEncoding.EncodeToString(body),
+	}
+}
+
+func TestCheckEntryMatchesPayloadAccepts(t *testing.T) {
+	envelope := []byte(`{"payload":"dGVzdA==","payloadType":"application/vnd.in-toto+json","signatures":[]}`)
+	payload := []byte("test")
+
+	entry := newHashedrekordEntry(t, payload)
+	hash, err := payloadHash(envelope)
+	if err != nil {
+		t.Fatalf("payloadHash() = %v", err)
+	}
+	if err := checkEntryMatchesPayload(entry, hash); err != nil {
+		t.Fatalf("checkEntryMatchesPayload() = %v, want nil", err)
+	}
+}
+
+func TestCheckEntryMatchesPayloadRejectsUnrelatedEntry(t *testing.T) {
+	envelope := []byte(`{"payload":"dGFtcGVyZWQ=","payloadType":"application/vnd.in-toto+json","signatures":[]}`)
+	hash, err := payloadHash(envelope)
+	if err != nil {
+		t.Fatalf("payloadHash() = %v", err)
+	}
+
+	// entry is a genuine, validly-signed Rekor entry, but for a different
+	// artifact than the one being verified.
+	entry := newHashedrekordEntry(t, []byte("some unrelated artifact"))
+
+	err = checkEntryMatchesPayload(entry, hash)
+	if !errors.Is(err, ErrPayloadMismatch) {
+		t.Fatalf("checkEntryMatchesPayload() = %v, want ErrPayloadMismatch", err)
+	}
+}
+
+func TestVerifyInclusionOfflineRejectsTamperedEnvelope(t *testing.T) {
+	// The attacker swaps in a real, validly-signed Rekor entry for an
+	// unrelated artifact alongside a forged envelope, hoping
+	// VerifyInclusionOffline only checks the entry's own internal
+	// consistency rather than cross-checking it against the envelope.
+	entry := newHashedrekordEntry(t, []byte("some unrelated artifact"))
+	forgedEnvelope := []byte(`{"payload":"Zm9yZ2Vk","payloadType":"application/vnd.in-toto+json","signatures":[]}`)
+
+	_, err := VerifyInclusionOffline(entry, forgedEnvelope, nil)
+	if !errors.Is(err, ErrPayloadMismatch) {
+		t.Fatalf("VerifyInclusionOffline() = %v, want ErrPayloadMismatch", err)
+	}
+}